@@ -0,0 +1,125 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package flogging
+
+import (
+	"os"
+	"strings"
+
+	"github.com/pkg/errors"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	"gopkg.in/natefinch/lumberjack.v2"
+)
+
+// Environment variables recognized by SetupLogging. They mirror the fields
+// of Config and let operators configure a peer or orderer's logging
+// pipeline without wiring any bespoke configuration code.
+const (
+	// EnvKeySpec sets the initial logging spec (see ModuleLevels.ActivateSpec).
+	EnvKeySpec = "FABRIC_LOGGING_SPEC"
+	// EnvKeyFormat selects the log record encoding ("json" or "console").
+	EnvKeyFormat = "FABRIC_LOGGING_FORMAT"
+	// EnvKeyFile is the path of a rotating log file sink. Ignored unless
+	// "file" is present in EnvKeyOutput.
+	EnvKeyFile = "FABRIC_LOGGING_FILE"
+	// EnvKeyOutput is a "+" separated list of sinks to fan log records out
+	// to. Recognized tokens are "stdout", "stderr", and "file"; any other
+	// token is treated as a URL and opened via a registered zap sink
+	// (see zap.RegisterSink).
+	EnvKeyOutput = "FABRIC_LOGGING_OUTPUT"
+	// EnvKeyLabels is a comma separated list of key=value pairs (e.g.
+	// "peer=peer0,org=org1") applied as base fields on every logger.
+	EnvKeyLabels = "FABRIC_LOGGING_LABELS"
+)
+
+// Config is used to configure the logging subsystem.
+type Config struct {
+	// Format is the log record encoding: "json" or "console". An empty
+	// value defaults to "console".
+	Format string
+
+	// LogSpec determines the logging level of each module. See
+	// ModuleLevels.ActivateSpec for the spec syntax. An empty value
+	// activates the package default (info for every module).
+	LogSpec string
+
+	// Labels is a comma separated list of key=value pairs applied as base
+	// fields on every logger created from this configuration.
+	Labels string
+
+	// Sinks are the write targets every configured core fans records out
+	// to. When empty, records are written to os.Stdout.
+	Sinks []zapcore.WriteSyncer
+}
+
+// SetupLogging initializes the package default Logging instance entirely
+// from the FABRIC_LOGGING_* environment variables. It is intended to be
+// called once during process startup so operators can configure a peer or
+// orderer's logging pipeline purely through the environment.
+func SetupLogging() error {
+	c, err := configFromEnvironment()
+	if err != nil {
+		return err
+	}
+	return Init(c)
+}
+
+func configFromEnvironment() (Config, error) {
+	sinks, err := sinksFromEnvironment(os.Getenv(EnvKeyOutput), os.Getenv(EnvKeyFile))
+	if err != nil {
+		return Config{}, err
+	}
+
+	return Config{
+		Format:  os.Getenv(EnvKeyFormat),
+		LogSpec: os.Getenv(EnvKeySpec),
+		Labels:  os.Getenv(EnvKeyLabels),
+		Sinks:   sinks,
+	}, nil
+}
+
+// sinksFromEnvironment parses a "+" separated FABRIC_LOGGING_OUTPUT value
+// (e.g. "stdout+stderr+file+tcp://localhost:1234") into write syncers. An
+// empty output defaults to "stdout".
+func sinksFromEnvironment(output, file string) ([]zapcore.WriteSyncer, error) {
+	if output == "" {
+		output = "stdout"
+	}
+
+	var urls []string
+	var sinks []zapcore.WriteSyncer
+	for _, token := range strings.Split(output, "+") {
+		switch token := strings.TrimSpace(token); token {
+		case "":
+			continue
+		case "stdout":
+			sinks = append(sinks, zapcore.Lock(os.Stdout))
+		case "stderr":
+			sinks = append(sinks, zapcore.Lock(os.Stderr))
+		case "file":
+			if file == "" {
+				return nil, errors.Errorf("logging output '%s' requires %s to be set", token, EnvKeyFile)
+			}
+			sinks = append(sinks, zapcore.AddSync(&lumberjack.Logger{Filename: file}))
+		default:
+			// Anything else is assumed to be a URL for a registered zap
+			// sink (file://, or a scheme registered with zap.RegisterSink).
+			urls = append(urls, token)
+		}
+	}
+
+	if len(urls) > 0 {
+		ws, _, err := zap.Open(urls...)
+		if err != nil {
+			return nil, errors.WithMessage(err, "failed to open logging output url")
+		}
+		sinks = append(sinks, ws)
+	}
+
+	return sinks, nil
+}
@@ -0,0 +1,51 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package flogging
+
+import (
+	"path/filepath"
+	"testing"
+)
+
+func TestSinksFromEnvironmentDefaultsToStdout(t *testing.T) {
+	sinks, err := sinksFromEnvironment("", "")
+	if err != nil {
+		t.Fatalf("sinksFromEnvironment returned an error: %s", err)
+	}
+	if len(sinks) != 1 {
+		t.Fatalf("expected a single default stdout sink, got %d", len(sinks))
+	}
+}
+
+func TestSinksFromEnvironmentParsesTokens(t *testing.T) {
+	file := filepath.Join(t.TempDir(), "fabric.log")
+
+	sinks, err := sinksFromEnvironment("stdout+stderr+file", file)
+	if err != nil {
+		t.Fatalf("sinksFromEnvironment returned an error: %s", err)
+	}
+	if len(sinks) != 3 {
+		t.Fatalf("expected three sinks (stdout, stderr, file), got %d", len(sinks))
+	}
+}
+
+func TestSinksFromEnvironmentIgnoresEmptyTokens(t *testing.T) {
+	sinks, err := sinksFromEnvironment("stdout++stderr", "")
+	if err != nil {
+		t.Fatalf("sinksFromEnvironment returned an error: %s", err)
+	}
+	if len(sinks) != 2 {
+		t.Fatalf("expected two sinks (stdout, stderr), got %d", len(sinks))
+	}
+}
+
+func TestSinksFromEnvironmentRequiresFileWhenFileTokenPresent(t *testing.T) {
+	_, err := sinksFromEnvironment("file", "")
+	if err == nil {
+		t.Fatalf("expected an error when 'file' is requested without %s set", EnvKeyFile)
+	}
+}
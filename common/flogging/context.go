@@ -0,0 +1,47 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package flogging
+
+import (
+	"context"
+
+	"go.uber.org/zap/zapcore"
+)
+
+type fieldsContextKey struct{}
+
+// WithFields returns a context derived from ctx that carries fields in
+// addition to any fields already bound to ctx. It does not mutate ctx, so
+// callers that branch a context in more than one direction do not observe
+// each other's fields.
+func WithFields(ctx context.Context, fields ...zapcore.Field) context.Context {
+	if len(fields) == 0 {
+		return ctx
+	}
+
+	existing := fieldsFromContext(ctx)
+	merged := make([]zapcore.Field, 0, len(existing)+len(fields))
+	merged = append(merged, existing...)
+	merged = append(merged, fields...)
+	return context.WithValue(ctx, fieldsContextKey{}, merged)
+}
+
+func fieldsFromContext(ctx context.Context) []zapcore.Field {
+	fields, _ := ctx.Value(fieldsContextKey{}).([]zapcore.Field)
+	return fields
+}
+
+// FromContext returns a FabricLogger named loggerName, carrying every field
+// bound to ctx via WithFields. Chaincode, endorser, and committer code can
+// call FromContext at any point during a transaction's lifecycle to emit
+// structured logs correlated by channel, transaction, or peer ID, without
+// threading a logger through every call by hand, while still getting a
+// named, per-module logger whose level can be controlled independently
+// (see ModuleLevels.SetLevel).
+func FromContext(ctx context.Context, loggerName string) *FabricLogger {
+	return MustGetLogger(loggerName).WithFields(fieldsFromContext(ctx)...)
+}
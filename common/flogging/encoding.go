@@ -0,0 +1,75 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package flogging
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/pkg/errors"
+	"go.uber.org/zap/zapcore"
+)
+
+// FormatJSON and FormatConsole are the recognized values of
+// FABRIC_LOGGING_FORMAT / Config.Format. Any other value is treated as
+// FormatConsole.
+const (
+	FormatJSON    = "json"
+	FormatConsole = "console"
+)
+
+// encoderConfig is the base zapcore.EncoderConfig shared by every encoding
+// fabric supports. It favors human readable timestamps and short,
+// single-line entries.
+var encoderConfig = zapcore.EncoderConfig{
+	TimeKey:        "time",
+	LevelKey:       "level",
+	NameKey:        "name",
+	CallerKey:      "caller",
+	MessageKey:     "msg",
+	StacktraceKey:  "stacktrace",
+	LineEnding:     zapcore.DefaultLineEnding,
+	EncodeLevel:    zapcore.CapitalLevelEncoder,
+	EncodeTime:     zapcore.ISO8601TimeEncoder,
+	EncodeDuration: zapcore.StringDurationEncoder,
+	EncodeCaller:   zapcore.ShortCallerEncoder,
+}
+
+// buildEncoder returns the zapcore.Encoder associated with a format name.
+// An empty or unrecognized format falls back to the console encoding.
+func buildEncoder(format string) zapcore.Encoder {
+	if format == FormatJSON {
+		return zapcore.NewJSONEncoder(encoderConfig)
+	}
+	return zapcore.NewConsoleEncoder(encoderConfig)
+}
+
+// parseLabels parses a comma separated list of key=value pairs, e.g.
+// "peer=peer0,org=org1", into zap fields that can be attached to every
+// logger as base context. Whitespace around keys and values is trimmed and
+// empty segments are ignored.
+func parseLabels(labels string) ([]zapcore.Field, error) {
+	var fields []zapcore.Field
+	for _, label := range strings.Split(labels, ",") {
+		label = strings.TrimSpace(label)
+		if label == "" {
+			continue
+		}
+		parts := strings.SplitN(label, "=", 2)
+		if len(parts) != 2 || parts[0] == "" {
+			return nil, errors.Errorf("invalid logging label '%s': expected key=value", label)
+		}
+		fields = append(fields, zapcore.Field{
+			Key:    strings.TrimSpace(parts[0]),
+			Type:   zapcore.StringType,
+			String: strings.TrimSpace(parts[1]),
+		})
+	}
+
+	sort.Slice(fields, func(i, j int) bool { return fields[i].Key < fields[j].Key })
+	return fields, nil
+}
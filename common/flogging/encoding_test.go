@@ -0,0 +1,65 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package flogging
+
+import (
+	"testing"
+
+	"go.uber.org/zap/zapcore"
+)
+
+func TestParseLabels(t *testing.T) {
+	fields, err := parseLabels(" peer=peer0 , org=org1 ")
+	if err != nil {
+		t.Fatalf("parseLabels returned an error: %s", err)
+	}
+
+	want := []zapcore.Field{
+		{Key: "org", Type: zapcore.StringType, String: "org1"},
+		{Key: "peer", Type: zapcore.StringType, String: "peer0"},
+	}
+	if len(fields) != len(want) {
+		t.Fatalf("expected %d fields, got %d: %v", len(want), len(fields), fields)
+	}
+	for i, f := range fields {
+		if f != want[i] {
+			t.Fatalf("field %d: expected %+v, got %+v", i, want[i], f)
+		}
+	}
+}
+
+func TestParseLabelsIgnoresEmptySegments(t *testing.T) {
+	fields, err := parseLabels(",, peer=peer0 ,,")
+	if err != nil {
+		t.Fatalf("parseLabels returned an error: %s", err)
+	}
+	if len(fields) != 1 || fields[0].Key != "peer" {
+		t.Fatalf("expected only the 'peer' label to survive, got %v", fields)
+	}
+}
+
+func TestParseLabelsEmptyString(t *testing.T) {
+	fields, err := parseLabels("")
+	if err != nil {
+		t.Fatalf("parseLabels returned an error: %s", err)
+	}
+	if len(fields) != 0 {
+		t.Fatalf("expected no fields, got %v", fields)
+	}
+}
+
+func TestParseLabelsRejectsMalformedPairs(t *testing.T) {
+	tests := []string{
+		"peer0",
+		"=peer0",
+	}
+	for _, labels := range tests {
+		if _, err := parseLabels(labels); err == nil {
+			t.Fatalf("expected parseLabels(%q) to return an error", labels)
+		}
+	}
+}
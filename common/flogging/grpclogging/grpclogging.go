@@ -0,0 +1,77 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+// Package grpclogging provides gRPC interceptors that extract correlation
+// information (channel ID, transaction ID, peer ID) from incoming request
+// metadata and bind it onto the request's context so that
+// flogging.FromContext returns a logger carrying it for the lifetime of
+// the call.
+package grpclogging
+
+import (
+	"context"
+
+	"github.com/hyperledger/fabric/common/flogging"
+	"go.uber.org/zap/zapcore"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+)
+
+// Metadata keys inspected on incoming requests. Any that are present are
+// attached as fields on the request-scoped logger.
+const (
+	ChannelIDKey = "channel-id"
+	TxIDKey      = "tx-id"
+	PeerIDKey    = "peer-id"
+)
+
+var correlationKeys = []string{ChannelIDKey, TxIDKey, PeerIDKey}
+
+// UnaryServerInterceptor binds correlation metadata found on the incoming
+// request onto the context passed to handler.
+func UnaryServerInterceptor() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		return handler(correlate(ctx), req)
+	}
+}
+
+// StreamServerInterceptor binds correlation metadata found on the stream's
+// incoming context onto a wrapped stream passed to handler.
+func StreamServerInterceptor() grpc.StreamServerInterceptor {
+	return func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		return handler(srv, &correlatedServerStream{ServerStream: ss, ctx: correlate(ss.Context())})
+	}
+}
+
+// correlatedServerStream overrides Context() so that handlers observe the
+// correlation fields bound by StreamServerInterceptor.
+type correlatedServerStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s *correlatedServerStream) Context() context.Context { return s.ctx }
+
+// correlate returns ctx with a flogging field bound for every correlation
+// metadata key present on the incoming request. If none are present, ctx
+// is returned unchanged.
+func correlate(ctx context.Context) context.Context {
+	md, ok := metadata.FromIncomingContext(ctx)
+	if !ok {
+		return ctx
+	}
+
+	var fields []zapcore.Field
+	for _, key := range correlationKeys {
+		values := md.Get(key)
+		if len(values) == 0 {
+			continue
+		}
+		fields = append(fields, zapcore.Field{Key: key, Type: zapcore.StringType, String: values[0]})
+	}
+
+	return flogging.WithFields(ctx, fields...)
+}
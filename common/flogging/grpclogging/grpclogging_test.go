@@ -0,0 +1,142 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package grpclogging
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hyperledger/fabric/common/flogging"
+	"go.uber.org/zap/zapcore"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+)
+
+// capturingCore records every entry written to it, together with the
+// fields bound to it via With plus whatever fields were passed at Write
+// time, mirroring how a real sink observes both a logger's base context
+// and its call-site fields.
+type capturingCore struct {
+	zapcore.LevelEnabler
+	base    []zapcore.Field
+	entries *[]zapcore.Entry
+	fields  *[][]zapcore.Field
+}
+
+func newCapturingCore() *capturingCore {
+	return &capturingCore{LevelEnabler: zapcore.DebugLevel, entries: &[]zapcore.Entry{}, fields: &[][]zapcore.Field{}}
+}
+
+func (c *capturingCore) With(fields []zapcore.Field) zapcore.Core {
+	merged := make([]zapcore.Field, 0, len(c.base)+len(fields))
+	merged = append(merged, c.base...)
+	merged = append(merged, fields...)
+	return &capturingCore{LevelEnabler: c.LevelEnabler, base: merged, entries: c.entries, fields: c.fields}
+}
+
+func (c *capturingCore) Check(e zapcore.Entry, ce *zapcore.CheckedEntry) *zapcore.CheckedEntry {
+	return ce.AddCore(e, c)
+}
+
+func (c *capturingCore) Write(e zapcore.Entry, fields []zapcore.Field) error {
+	merged := make([]zapcore.Field, 0, len(c.base)+len(fields))
+	merged = append(merged, c.base...)
+	merged = append(merged, fields...)
+	*c.entries = append(*c.entries, e)
+	*c.fields = append(*c.fields, merged)
+	return nil
+}
+
+func (c *capturingCore) Sync() error { return nil }
+
+func (c *capturingCore) fieldValue(key string) (string, bool) {
+	for _, fields := range *c.fields {
+		for _, f := range fields {
+			if f.Key == key {
+				return f.String, true
+			}
+		}
+	}
+	return "", false
+}
+
+func TestUnaryServerInterceptorBindsMetadataFields(t *testing.T) {
+	if err := flogging.Init(flogging.Config{LogSpec: "debug"}); err != nil {
+		t.Fatalf("flogging.Init returned an error: %s", err)
+	}
+	captured := newCapturingCore()
+	flogging.AddCore("captured", captured)
+	defer flogging.RemoveCore("captured")
+
+	ctx := metadata.NewIncomingContext(context.Background(), metadata.Pairs(
+		ChannelIDKey, "mychannel",
+		TxIDKey, "mytx",
+	))
+
+	var observed context.Context
+	interceptor := UnaryServerInterceptor()
+	_, err := interceptor(ctx, nil, &grpc.UnaryServerInfo{}, func(ctx context.Context, req interface{}) (interface{}, error) {
+		observed = ctx
+		return nil, nil
+	})
+	if err != nil {
+		t.Fatalf("interceptor returned an error: %s", err)
+	}
+
+	flogging.FromContext(observed, "test").Info("handled")
+
+	if got, ok := captured.fieldValue(ChannelIDKey); !ok || got != "mychannel" {
+		t.Fatalf("expected %s field to be 'mychannel', got %q (present: %v)", ChannelIDKey, got, ok)
+	}
+	if got, ok := captured.fieldValue(TxIDKey); !ok || got != "mytx" {
+		t.Fatalf("expected %s field to be 'mytx', got %q (present: %v)", TxIDKey, got, ok)
+	}
+}
+
+func TestUnaryServerInterceptorPassesThroughWithoutMetadata(t *testing.T) {
+	ctx := context.Background()
+
+	var observed context.Context
+	interceptor := UnaryServerInterceptor()
+	_, err := interceptor(ctx, nil, &grpc.UnaryServerInfo{}, func(ctx context.Context, req interface{}) (interface{}, error) {
+		observed = ctx
+		return nil, nil
+	})
+	if err != nil {
+		t.Fatalf("interceptor returned an error: %s", err)
+	}
+
+	if observed != ctx {
+		t.Fatalf("expected the context to be passed through unchanged when no metadata is present")
+	}
+}
+
+type fakeServerStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s *fakeServerStream) Context() context.Context { return s.ctx }
+
+func TestStreamServerInterceptorWrapsContext(t *testing.T) {
+	ctx := metadata.NewIncomingContext(context.Background(), metadata.Pairs(PeerIDKey, "peer0"))
+	ss := &fakeServerStream{ctx: ctx}
+
+	var observed grpc.ServerStream
+	interceptor := StreamServerInterceptor()
+	err := interceptor(nil, ss, &grpc.StreamServerInfo{}, func(srv interface{}, stream grpc.ServerStream) error {
+		observed = stream
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("interceptor returned an error: %s", err)
+	}
+
+	if observed.Context() == ctx {
+		t.Fatalf("expected Context() to return the correlated context, not the original incoming context")
+	}
+}
@@ -0,0 +1,147 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+// Package httpadmin provides http.Handler implementations that expose
+// flogging's dynamic logging controls so that an operator can inspect and
+// change the logging level of a running peer or orderer without a
+// restart.
+package httpadmin
+
+import (
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"strings"
+
+	"github.com/hyperledger/fabric/common/flogging"
+	"go.uber.org/zap/zapcore"
+)
+
+// specGetterSetter is the subset of *flogging.Logging used by the spec
+// handler. It is satisfied by *flogging.Logging.
+type specGetterSetter interface {
+	Spec() string
+	ActivateSpec(spec string) error
+}
+
+// NewSpecHandler creates an http.Handler that serves the active logging
+// spec of the package default Logging instance.
+//
+//   GET  /logspec         returns the active spec
+//   PUT  /logspec         activates the spec in the request body
+func NewSpecHandler() http.Handler {
+	return &specHandler{logging: flogging.Global()}
+}
+
+type specHandler struct {
+	logging specGetterSetter
+}
+
+func (h *specHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	switch r.Method {
+	case http.MethodGet:
+		fmt.Fprintln(w, h.logging.Spec())
+
+	case http.MethodPut:
+		body, err := ioutil.ReadAll(r.Body)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		if err := h.logging.ActivateSpec(strings.TrimSpace(string(body))); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		fmt.Fprintln(w, h.logging.Spec())
+
+	default:
+		w.Header().Set("Allow", "GET, PUT")
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+	}
+}
+
+// levelSetter is the subset of *flogging.Logging used by the logger level
+// handler. It is satisfied by *flogging.Logging.
+type levelSetter interface {
+	SetLevel(loggerName string, lvl zapcore.Level)
+	SetLevels(pattern string, lvl zapcore.Level) ([]string, error)
+	ResetLevel(loggerName string)
+}
+
+// NewLoggerLevelHandler creates an http.Handler that sets or resets the
+// level of one or more loggers on the package default Logging instance.
+//
+//   PUT /loggers/{name}?level=debug     sets {name}'s level to debug
+//   PUT /loggers/{name}                 resets {name} to the active spec's level
+//   PUT /loggers?pattern=<regex>&level=debug
+//                                        sets the level of every known logger
+//                                        whose name matches the regex
+//
+// {name} is everything following the "/loggers/" prefix, so it may itself
+// contain periods (e.g. "gossip.state").
+func NewLoggerLevelHandler() http.Handler {
+	return &loggerLevelHandler{logging: flogging.Global()}
+}
+
+type loggerLevelHandler struct {
+	logging levelSetter
+}
+
+func (h *loggerLevelHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPut {
+		w.Header().Set("Allow", "PUT")
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	if pattern := r.URL.Query().Get("pattern"); pattern != "" {
+		h.servePattern(w, r, pattern)
+		return
+	}
+
+	name := strings.TrimPrefix(r.URL.Path, "/loggers/")
+	if name == "" {
+		http.Error(w, "a logger name or pattern is required", http.StatusBadRequest)
+		return
+	}
+
+	level := r.URL.Query().Get("level")
+	if level == "" {
+		h.logging.ResetLevel(name)
+		fmt.Fprintf(w, "logger '%s' reset to the spec's level\n", name)
+		return
+	}
+
+	if !flogging.IsValidLevel(level) {
+		http.Error(w, fmt.Sprintf("invalid logging level '%s'", level), http.StatusBadRequest)
+		return
+	}
+
+	h.logging.SetLevel(name, flogging.NameToLevel(level))
+	fmt.Fprintf(w, "logger '%s' set to level '%s'\n", name, level)
+}
+
+// servePattern handles PUT /loggers?pattern=<regex>&level=<level>, setting
+// the level of every known logger whose name matches pattern.
+func (h *loggerLevelHandler) servePattern(w http.ResponseWriter, r *http.Request, pattern string) {
+	level := r.URL.Query().Get("level")
+	if level == "" {
+		http.Error(w, "a level is required when setting loggers by pattern", http.StatusBadRequest)
+		return
+	}
+	if !flogging.IsValidLevel(level) {
+		http.Error(w, fmt.Sprintf("invalid logging level '%s'", level), http.StatusBadRequest)
+		return
+	}
+
+	matched, err := h.logging.SetLevels(pattern, flogging.NameToLevel(level))
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	fmt.Fprintf(w, "loggers matching '%s' set to level '%s': %s\n", pattern, level, strings.Join(matched, ", "))
+}
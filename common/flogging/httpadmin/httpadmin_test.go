@@ -0,0 +1,215 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package httpadmin
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"regexp"
+	"strings"
+	"testing"
+
+	"go.uber.org/zap/zapcore"
+)
+
+type fakeSpecGetterSetter struct {
+	spec        string
+	activateErr error
+}
+
+func (f *fakeSpecGetterSetter) Spec() string { return f.spec }
+
+func (f *fakeSpecGetterSetter) ActivateSpec(spec string) error {
+	if f.activateErr != nil {
+		return f.activateErr
+	}
+	f.spec = spec
+	return nil
+}
+
+func TestSpecHandlerGetReturnsActiveSpec(t *testing.T) {
+	h := &specHandler{logging: &fakeSpecGetterSetter{spec: "info"}}
+
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/logspec", nil))
+
+	if rec.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d", rec.Code)
+	}
+	if got := strings.TrimSpace(rec.Body.String()); got != "info" {
+		t.Fatalf("expected body 'info', got %q", got)
+	}
+}
+
+func TestSpecHandlerPutActivatesSpec(t *testing.T) {
+	fake := &fakeSpecGetterSetter{spec: "info"}
+	h := &specHandler{logging: fake}
+
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, httptest.NewRequest(http.MethodPut, "/logspec", strings.NewReader("debug")))
+
+	if rec.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d", rec.Code)
+	}
+	if fake.spec != "debug" {
+		t.Fatalf("expected the spec to be activated to 'debug', got %q", fake.spec)
+	}
+}
+
+func TestSpecHandlerPutRejectsInvalidSpec(t *testing.T) {
+	fake := &fakeSpecGetterSetter{spec: "info", activateErr: errors.New("invalid logging specification")}
+	h := &specHandler{logging: fake}
+
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, httptest.NewRequest(http.MethodPut, "/logspec", strings.NewReader("nonsense")))
+
+	if rec.Code != http.StatusBadRequest {
+		t.Fatalf("expected 400, got %d", rec.Code)
+	}
+}
+
+func TestSpecHandlerRejectsWrongMethod(t *testing.T) {
+	h := &specHandler{logging: &fakeSpecGetterSetter{spec: "info"}}
+
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/logspec", nil))
+
+	if rec.Code != http.StatusMethodNotAllowed {
+		t.Fatalf("expected 405, got %d", rec.Code)
+	}
+}
+
+// fakeLevelSetter is a minimal levelSetter backed by a plain map, used to
+// exercise loggerLevelHandler's HTTP plumbing (routing, status codes, query
+// parsing) independently of ModuleLevels' own behavior.
+type fakeLevelSetter struct {
+	levels map[string]zapcore.Level
+}
+
+func (f *fakeLevelSetter) SetLevel(loggerName string, lvl zapcore.Level) {
+	f.levels[loggerName] = lvl
+}
+
+func (f *fakeLevelSetter) ResetLevel(loggerName string) {
+	delete(f.levels, loggerName)
+}
+
+func (f *fakeLevelSetter) SetLevels(pattern string, lvl zapcore.Level) ([]string, error) {
+	re, err := regexp.Compile(pattern)
+	if err != nil {
+		return nil, err
+	}
+	var matched []string
+	for name := range f.levels {
+		if re.MatchString(name) {
+			f.levels[name] = lvl
+			matched = append(matched, name)
+		}
+	}
+	return matched, nil
+}
+
+func TestLoggerLevelHandlerSetsLevelByName(t *testing.T) {
+	fake := &fakeLevelSetter{levels: map[string]zapcore.Level{}}
+	h := &loggerLevelHandler{logging: fake}
+
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, httptest.NewRequest(http.MethodPut, "/loggers/gossip.state?level=debug", nil))
+
+	if rec.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d: %s", rec.Code, rec.Body.String())
+	}
+	if fake.levels["gossip.state"] != zapcore.DebugLevel {
+		t.Fatalf("expected gossip.state to be set to debug, got %s", fake.levels["gossip.state"])
+	}
+}
+
+func TestLoggerLevelHandlerResetsLevelByName(t *testing.T) {
+	fake := &fakeLevelSetter{levels: map[string]zapcore.Level{"gossip.state": zapcore.DebugLevel}}
+	h := &loggerLevelHandler{logging: fake}
+
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, httptest.NewRequest(http.MethodPut, "/loggers/gossip.state", nil))
+
+	if rec.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d: %s", rec.Code, rec.Body.String())
+	}
+	if _, ok := fake.levels["gossip.state"]; ok {
+		t.Fatalf("expected gossip.state to be reset")
+	}
+}
+
+func TestLoggerLevelHandlerRejectsInvalidLevel(t *testing.T) {
+	h := &loggerLevelHandler{logging: &fakeLevelSetter{levels: map[string]zapcore.Level{}}}
+
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, httptest.NewRequest(http.MethodPut, "/loggers/gossip.state?level=nonsense", nil))
+
+	if rec.Code != http.StatusBadRequest {
+		t.Fatalf("expected 400, got %d", rec.Code)
+	}
+}
+
+func TestLoggerLevelHandlerRejectsMissingName(t *testing.T) {
+	h := &loggerLevelHandler{logging: &fakeLevelSetter{levels: map[string]zapcore.Level{}}}
+
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, httptest.NewRequest(http.MethodPut, "/loggers/", nil))
+
+	if rec.Code != http.StatusBadRequest {
+		t.Fatalf("expected 400, got %d", rec.Code)
+	}
+}
+
+func TestLoggerLevelHandlerSetsLevelsByPattern(t *testing.T) {
+	fake := &fakeLevelSetter{levels: map[string]zapcore.Level{"gossip.state": zapcore.InfoLevel}}
+	h := &loggerLevelHandler{logging: fake}
+
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, httptest.NewRequest(http.MethodPut, "/loggers?pattern=%5Egossip%5C.&level=debug", nil))
+
+	if rec.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d: %s", rec.Code, rec.Body.String())
+	}
+	if fake.levels["gossip.state"] != zapcore.DebugLevel {
+		t.Fatalf("expected gossip.state to be matched and set to debug, got %v", fake.levels)
+	}
+}
+
+func TestLoggerLevelHandlerRejectsPatternWithoutLevel(t *testing.T) {
+	h := &loggerLevelHandler{logging: &fakeLevelSetter{levels: map[string]zapcore.Level{}}}
+
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, httptest.NewRequest(http.MethodPut, "/loggers?pattern=%5Egossip%5C.", nil))
+
+	if rec.Code != http.StatusBadRequest {
+		t.Fatalf("expected 400, got %d", rec.Code)
+	}
+}
+
+func TestLoggerLevelHandlerRejectsInvalidPattern(t *testing.T) {
+	h := &loggerLevelHandler{logging: &fakeLevelSetter{levels: map[string]zapcore.Level{}}}
+
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, httptest.NewRequest(http.MethodPut, "/loggers?pattern=%5B&level=debug", nil))
+
+	if rec.Code != http.StatusBadRequest {
+		t.Fatalf("expected 400, got %d", rec.Code)
+	}
+}
+
+func TestLoggerLevelHandlerRejectsWrongMethod(t *testing.T) {
+	h := &loggerLevelHandler{logging: &fakeLevelSetter{levels: map[string]zapcore.Level{}}}
+
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/loggers/gossip.state", nil))
+
+	if rec.Code != http.StatusMethodNotAllowed {
+		t.Fatalf("expected 405, got %d", rec.Code)
+	}
+}
@@ -0,0 +1,43 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package flogging
+
+import (
+	"strings"
+
+	"go.uber.org/zap/zapcore"
+)
+
+// namesToLevels maps the case-insensitive level names accepted in a logging
+// spec to their zapcore.Level. An empty name is treated as info, matching
+// the zero value of zapcore.Level.
+var namesToLevels = map[string]zapcore.Level{
+	"PANIC":   zapcore.PanicLevel,
+	"FATAL":   zapcore.FatalLevel,
+	"ERROR":   zapcore.ErrorLevel,
+	"WARN":    zapcore.WarnLevel,
+	"WARNING": zapcore.WarnLevel,
+	"INFO":    zapcore.InfoLevel,
+	"":        zapcore.InfoLevel,
+	"DEBUG":   zapcore.DebugLevel,
+}
+
+// NameToLevel converts a level name into its zapcore.Level. Unrecognized
+// names are treated as DebugLevel so that typos fail open toward more
+// verbose logging rather than silently disabling it.
+func NameToLevel(name string) zapcore.Level {
+	if level, ok := namesToLevels[strings.ToUpper(name)]; ok {
+		return level
+	}
+	return zapcore.DebugLevel
+}
+
+// IsValidLevel returns whether name is a recognized logging level.
+func IsValidLevel(name string) bool {
+	_, ok := namesToLevels[strings.ToUpper(name)]
+	return ok
+}
@@ -0,0 +1,80 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package flogging
+
+import (
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+// FabricLogger is the logging client used throughout Fabric. It wraps a zap
+// sugared logger so the rest of the codebase does not need to depend
+// directly on zap.
+type FabricLogger struct {
+	s *zap.SugaredLogger
+}
+
+// NewFabricLogger creates a FabricLogger that wraps a zap logger, with the
+// given zap options (for example zap.AddCallerSkip) applied to it.
+func NewFabricLogger(l *zap.Logger, options ...zap.Option) *FabricLogger {
+	return &FabricLogger{s: l.WithOptions(options...).Sugar()}
+}
+
+func (f *FabricLogger) DPanic(args ...interface{})                    { f.s.DPanic(args...) }
+func (f *FabricLogger) DPanicf(template string, args ...interface{})  { f.s.DPanicf(template, args...) }
+func (f *FabricLogger) Debug(args ...interface{})                     { f.s.Debug(args...) }
+func (f *FabricLogger) Debugf(template string, args ...interface{})   { f.s.Debugf(template, args...) }
+func (f *FabricLogger) Error(args ...interface{})                     { f.s.Error(args...) }
+func (f *FabricLogger) Errorf(template string, args ...interface{})   { f.s.Errorf(template, args...) }
+func (f *FabricLogger) Fatal(args ...interface{})                     { f.s.Fatal(args...) }
+func (f *FabricLogger) Fatalf(template string, args ...interface{})   { f.s.Fatalf(template, args...) }
+func (f *FabricLogger) Info(args ...interface{})                      { f.s.Info(args...) }
+func (f *FabricLogger) Infof(template string, args ...interface{})    { f.s.Infof(template, args...) }
+func (f *FabricLogger) Panic(args ...interface{})                     { f.s.Panic(args...) }
+func (f *FabricLogger) Panicf(template string, args ...interface{})   { f.s.Panicf(template, args...) }
+func (f *FabricLogger) Warn(args ...interface{})                      { f.s.Warn(args...) }
+func (f *FabricLogger) Warnf(template string, args ...interface{})    { f.s.Warnf(template, args...) }
+func (f *FabricLogger) Warning(args ...interface{})                   { f.s.Warn(args...) }
+
+// Named returns a new FabricLogger with the specified name appended to the
+// existing logger's name.
+func (f *FabricLogger) Named(name string) *FabricLogger {
+	return &FabricLogger{s: f.s.Named(name)}
+}
+
+// With returns a new FabricLogger with the specified key-value pairs
+// added as context to every subsequent log line. It does not mutate the
+// receiver.
+func (f *FabricLogger) With(args ...interface{}) *FabricLogger {
+	return &FabricLogger{s: f.s.With(args...)}
+}
+
+// WithFields returns a new FabricLogger that has fields added to its
+// context in addition to any already present. It does not mutate the
+// receiver, so the parent logger's state is left untouched.
+func (f *FabricLogger) WithFields(fields ...zapcore.Field) *FabricLogger {
+	if len(fields) == 0 {
+		return f
+	}
+	return &FabricLogger{s: f.s.Desugar().With(fields...).Sugar()}
+}
+
+// WithOptions returns a new FabricLogger with the specified zap.Options
+// applied. It does not mutate the receiver.
+func (f *FabricLogger) WithOptions(opts ...zap.Option) *FabricLogger {
+	l := f.s.Desugar().WithOptions(opts...)
+	return &FabricLogger{s: l.Sugar()}
+}
+
+// Name returns the name of the underlying logger.
+func (f *FabricLogger) Name() string { return f.s.Desugar().Name() }
+
+// IsEnabledFor returns whether logging is enabled for the given level on
+// this logger.
+func (f *FabricLogger) IsEnabledFor(level zapcore.Level) bool {
+	return f.s.Desugar().Core().Enabled(level)
+}
@@ -0,0 +1,240 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package flogging
+
+import (
+	"os"
+	"sync"
+
+	"github.com/pkg/errors"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+// Logging is the central logging facility used by Fabric processes. It owns
+// the active ModuleLevels (which logger is at which level) as well as the
+// MultiCore that every FabricLogger ultimately writes to.
+type Logging struct {
+	*ModuleLevels
+
+	mutex     sync.RWMutex
+	encoder   zapcore.Encoder
+	multiCore *MultiCore
+	labels    []zapcore.Field
+}
+
+// New creates a Logging instance from the provided Config. The returned
+// instance is not installed as the package default; call Init to do so.
+func New(c Config) (*Logging, error) {
+	labels, err := parseLabels(c.Labels)
+	if err != nil {
+		return nil, err
+	}
+
+	encoder := buildEncoder(c.Format)
+
+	cores := make([]zapcore.Core, 0, len(c.Sinks))
+	for _, sink := range c.Sinks {
+		cores = append(cores, zapcore.NewCore(encoder, sink, zapcore.DebugLevel))
+	}
+	if len(cores) == 0 {
+		cores = append(cores, zapcore.NewCore(encoder, zapcore.Lock(os.Stdout), zapcore.DebugLevel))
+	}
+
+	l := &Logging{
+		ModuleLevels: &ModuleLevels{},
+		encoder:      encoder,
+		multiCore:    NewMultiCore(map[string]zapcore.Core{"default": zapcore.NewTee(cores...)}),
+		labels:       labels,
+	}
+	if err := l.ActivateSpec(c.LogSpec); err != nil {
+		return nil, err
+	}
+
+	return l, nil
+}
+
+// AddCore attaches c under name so that every logger begins writing to it
+// immediately, without being rebuilt. Typical uses include an in-memory
+// ring buffer core for crash dumps, a syslog/GELF core, or a test-capture
+// core.
+func (l *Logging) AddCore(name string, c zapcore.Core) {
+	l.multiCore.AddCore(name, c)
+}
+
+// RemoveCore detaches the core previously attached under name, if any.
+func (l *Logging) RemoveCore(name string) {
+	l.multiCore.RemoveCore(name)
+}
+
+// ZapLogger returns a zap logger for the given name whose level is governed
+// by the associated ModuleLevels entry and whose output carries the
+// configured base labels.
+func (l *Logging) ZapLogger(name string) *zap.Logger {
+	l.ModuleLevels.Register(name)
+
+	l.mutex.RLock()
+	labels := l.labels
+	l.mutex.RUnlock()
+
+	enabler := zap.LevelEnablerFunc(func(lvl zapcore.Level) bool {
+		return lvl >= l.Level(name)
+	})
+
+	var core zapcore.Core = &moduleLevelCore{Core: l.multiCore, enabler: enabler}
+	core = &samplingCore{inner: core, levels: l.ModuleLevels, loggerName: name}
+
+	logger := zap.New(core, zap.AddCaller())
+	if name != "" {
+		logger = logger.Named(name)
+	}
+	if len(labels) > 0 {
+		logger = logger.With(labels...)
+	}
+	return logger
+}
+
+// Logger returns a FabricLogger for the given name.
+func (l *Logging) Logger(name string) *FabricLogger {
+	return NewFabricLogger(l.ZapLogger(name), zap.AddCallerSkip(1))
+}
+
+// moduleLevelCore wraps a zapcore.Core and substitutes the level enabler
+// with one that consults ModuleLevels for the wrapped logger's name.
+type moduleLevelCore struct {
+	zapcore.Core
+	enabler zapcore.LevelEnabler
+}
+
+func (c *moduleLevelCore) Enabled(lvl zapcore.Level) bool { return c.enabler.Enabled(lvl) }
+
+func (c *moduleLevelCore) Check(e zapcore.Entry, ce *zapcore.CheckedEntry) *zapcore.CheckedEntry {
+	if c.enabler.Enabled(e.Level) {
+		return c.Core.Check(e, ce)
+	}
+	return ce
+}
+
+func (c *moduleLevelCore) With(fields []zapcore.Field) zapcore.Core {
+	return &moduleLevelCore{Core: c.Core.With(fields), enabler: c.enabler}
+}
+
+// samplingCore wraps inner with whatever sampler configuration ModuleLevels
+// currently resolves for loggerName, re-consulting it on every Check. This
+// mirrors moduleLevelCore's live level lookup: a later ActivateSpec that
+// adds, changes, or removes a logger's "@first,thereafter/interval"
+// annotation now takes effect immediately, even for a *zap.Logger obtained
+// before the change -- the common case for a package-level
+// `var logger = flogging.MustGetLogger(...)`. The constructed
+// zapcore.NewSamplerWithOptions core is cached and only rebuilt when the
+// resolved SamplerConfig changes, so an unchanged spec does not pay for a
+// new sampler, and its counters, on every log statement.
+type samplingCore struct {
+	inner      zapcore.Core
+	levels     *ModuleLevels
+	loggerName string
+
+	mutex   sync.Mutex
+	current SamplerConfig
+	sampled zapcore.Core
+}
+
+func (c *samplingCore) resolve() zapcore.Core {
+	sc, ok := c.levels.Sampler(c.loggerName)
+	if !ok {
+		return c.inner
+	}
+
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	if c.sampled == nil || c.current != sc {
+		c.current = sc
+		c.sampled = zapcore.NewSamplerWithOptions(c.inner, sc.Interval, sc.First, sc.Thereafter)
+	}
+	return c.sampled
+}
+
+func (c *samplingCore) Enabled(lvl zapcore.Level) bool { return c.resolve().Enabled(lvl) }
+
+func (c *samplingCore) Check(e zapcore.Entry, ce *zapcore.CheckedEntry) *zapcore.CheckedEntry {
+	return c.resolve().Check(e, ce)
+}
+
+func (c *samplingCore) With(fields []zapcore.Field) zapcore.Core {
+	return &samplingCore{inner: c.inner.With(fields), levels: c.levels, loggerName: c.loggerName}
+}
+
+func (c *samplingCore) Write(e zapcore.Entry, fields []zapcore.Field) error {
+	return c.resolve().Write(e, fields)
+}
+
+func (c *samplingCore) Sync() error { return c.inner.Sync() }
+
+var (
+	loggingMutex sync.Mutex
+	logging      *Logging
+)
+
+// Init installs c as the package default logging configuration. It is
+// typically called once, early in process startup (see SetupLogging), but
+// may be called again to reconfigure logging, for example in tests.
+func Init(c Config) error {
+	l, err := New(c)
+	if err != nil {
+		return err
+	}
+
+	loggingMutex.Lock()
+	logging = l
+	loggingMutex.Unlock()
+	return nil
+}
+
+// Global returns the process-wide Logging instance, initializing it with
+// default settings if Init has not yet been called.
+func Global() *Logging {
+	loggingMutex.Lock()
+	defer loggingMutex.Unlock()
+
+	if logging == nil {
+		l, err := New(Config{})
+		if err != nil {
+			panic(errors.WithMessage(err, "failed to initialize default logging configuration"))
+		}
+		logging = l
+	}
+	return logging
+}
+
+// MustGetLogger creates a FabricLogger with the given name using the
+// package default Logging instance.
+func MustGetLogger(loggerName string) *FabricLogger {
+	return Global().Logger(loggerName)
+}
+
+// ActivateSpec activates a logging specification on the package default
+// Logging instance.
+func ActivateSpec(spec string) error {
+	return Global().ActivateSpec(spec)
+}
+
+// AddCore attaches c under name on the package default Logging instance.
+func AddCore(name string, c zapcore.Core) {
+	Global().AddCore(name, c)
+}
+
+// RemoveCore detaches the core previously attached under name on the
+// package default Logging instance.
+func RemoveCore(name string) {
+	Global().RemoveCore(name)
+}
+
+// SetDefaultSampler sets the sampler configuration applied to loggers that
+// resolve to the default level, on the package default Logging instance.
+func SetDefaultSampler(sc SamplerConfig) {
+	Global().SetDefaultSampler(sc)
+}
@@ -0,0 +1,51 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package flogging
+
+import (
+	"testing"
+
+	"go.uber.org/zap/zapcore"
+)
+
+func TestLoggingAddCoreRespectsCoreLevelThroughModuleLevelCore(t *testing.T) {
+	l, err := New(Config{LogSpec: "debug"})
+	if err != nil {
+		t.Fatalf("New returned an error: %s", err)
+	}
+
+	strict := &capturingCore{LevelEnabler: zapcore.WarnLevel, entries: &[]zapcore.Entry{}}
+	l.AddCore("strict", strict)
+
+	logger := l.Logger("gossip")
+	logger.Debug("below the sideband core's own level")
+	logger.Warn("at the sideband core's own level")
+
+	if len(*strict.entries) != 1 {
+		t.Fatalf("expected the strict core's own WarnLevel to still apply once moduleLevelCore passed the record through, got %d entries", len(*strict.entries))
+	}
+}
+
+func TestLoggingAddCoreReachesLoggersCreatedAfterLabelsWereBound(t *testing.T) {
+	l, err := New(Config{LogSpec: "debug", Labels: "org=org1"})
+	if err != nil {
+		t.Fatalf("New returned an error: %s", err)
+	}
+
+	// The logger is created -- and labels are bound onto its core via
+	// ZapLogger's logger.With(labels...) -- before AddCore is called.
+	logger := l.Logger("gossip")
+
+	sideband := newCapturingCore()
+	l.AddCore("sideband", sideband)
+
+	logger.Debug("hello")
+
+	if len(*sideband.entries) != 1 {
+		t.Fatalf("expected a core attached after a labeled logger was built to still receive its records, got %d entries", len(*sideband.entries))
+	}
+}
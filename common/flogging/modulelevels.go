@@ -10,20 +10,50 @@ import (
 	"fmt"
 	"regexp"
 	"sort"
+	"strconv"
 	"strings"
 	"sync"
+	"time"
 
 	"github.com/pkg/errors"
 	"go.uber.org/zap/zapcore"
 )
 
+// SamplerConfig configures the zapcore.NewSamplerWithOptions sampler
+// applied to a logger: the first N records with a given level and message
+// within each interval are logged verbatim, and every thereafter-th record after that
+// is logged, so that a logger under storm conditions still emits periodic
+// evidence without drowning its sinks.
+type SamplerConfig struct {
+	First      int
+	Thereafter int
+	Interval   time.Duration
+}
+
 // ModuleLevels tracks the logging level of logging modules.
 type ModuleLevels struct {
-	defaultLevel zapcore.Level
+	defaultLevel   zapcore.Level
+	defaultSampler *SamplerConfig
 
 	mutex      sync.RWMutex
 	levelCache map[string]zapcore.Level
+	registered map[string]struct{}
 	specs      map[string]zapcore.Level
+	samplers   map[string]SamplerConfig
+}
+
+// Register records that loggerName is a known logger, independent of
+// whether it has ever been queried for its level. Registration persists
+// across ActivateSpec calls, unlike the level cache, so that SetLevels can
+// find and update a logger that exists but has not logged yet, or has not
+// logged since the most recent spec reload.
+func (m *ModuleLevels) Register(loggerName string) {
+	m.mutex.Lock()
+	if m.registered == nil {
+		m.registered = map[string]struct{}{}
+	}
+	m.registered[loggerName] = struct{}{}
+	m.mutex.Unlock()
 }
 
 // DefaultLevel returns the default logging level for modules that do not have
@@ -38,31 +68,50 @@ func (m *ModuleLevels) DefaultLevel() zapcore.Level {
 // ActivateSpec is used to modify logging levels.
 //
 // The logging specification has the following form:
-//   [<logger>[,<logger>...]=]<level>[:[<logger>[,<logger>...]=]<level>...]
+//   [<logger>[,<logger>...]=]<level>[@<first>,<thereafter>/<interval>][:[<logger>[,<logger>...]=]<level>[@<first>,<thereafter>/<interval>]...]
+//
+// The optional "@<first>,<thereafter>/<interval>" suffix on a segment's
+// level configures sampling for the loggers in that segment: the first
+// <first> records with the same level and message logged in each
+// <interval> window are emitted verbatim,
+// and every <thereafter>-th record after that is emitted, e.g.
+// "gossip=debug@100,100/1s:info" samples the gossip logger at debug but
+// leaves every other logger, at the unsampled default info level, alone.
 func (m *ModuleLevels) ActivateSpec(spec string) error {
 	m.mutex.Lock()
 	defer m.mutex.Unlock()
 
 	defaultLevel := zapcore.InfoLevel
+	var defaultSampler *SamplerConfig
 	specs := map[string]zapcore.Level{}
+	samplers := map[string]SamplerConfig{}
 	for _, field := range strings.Split(spec, ":") {
 		split := strings.Split(field, "=")
 		switch len(split) {
-		case 1: // level
-			if field != "" && !IsValidLevel(field) {
+		case 1: // level[@sampler]
+			levelName, sampler, err := splitLevelAndSampler(field)
+			if err != nil {
+				return errors.Errorf("invalid logging specification '%s': %s", spec, err)
+			}
+			if levelName != "" && !IsValidLevel(levelName) {
 				return errors.Errorf("invalid logging specification '%s': bad segment '%s'", spec, field)
 			}
-			defaultLevel = NameToLevel(field)
+			defaultLevel = NameToLevel(levelName)
+			defaultSampler = sampler
 
-		case 2: // <logger>[,<logger>...]=<level>
+		case 2: // <logger>[,<logger>...]=<level>[@sampler]
 			if split[0] == "" {
 				return errors.Errorf("invalid logging specification '%s': no logger specified in segment '%s'", spec, field)
 			}
-			if field != "" && !IsValidLevel(split[1]) {
+			levelName, sampler, err := splitLevelAndSampler(split[1])
+			if err != nil {
+				return errors.Errorf("invalid logging specification '%s': %s", spec, err)
+			}
+			if levelName != "" && !IsValidLevel(levelName) {
 				return errors.Errorf("invalid logging specification '%s': bad segment '%s'", spec, field)
 			}
 
-			level := NameToLevel(split[1])
+			level := NameToLevel(levelName)
 			loggers := strings.Split(split[0], ",")
 			for _, logger := range loggers {
 				// check if the logger name in the spec is valid. The
@@ -73,6 +122,9 @@ func (m *ModuleLevels) ActivateSpec(spec string) error {
 					return errors.Errorf("invalid logging specification '%s': bad logger name '%s'", spec, logger)
 				}
 				specs[logger] = level
+				if sampler != nil {
+					samplers[logger] = *sampler
+				}
 			}
 
 		default:
@@ -81,12 +133,69 @@ func (m *ModuleLevels) ActivateSpec(spec string) error {
 	}
 
 	m.defaultLevel = defaultLevel
+	m.defaultSampler = defaultSampler
 	m.specs = specs
+	m.samplers = samplers
 	m.levelCache = map[string]zapcore.Level{}
 
 	return nil
 }
 
+// splitLevelAndSampler separates a segment's level name from its optional
+// "@<first>,<thereafter>/<interval>" sampler annotation.
+func splitLevelAndSampler(token string) (level string, sampler *SamplerConfig, err error) {
+	idx := strings.Index(token, "@")
+	if idx < 0 {
+		return token, nil, nil
+	}
+
+	sc, err := parseSampler(token[idx+1:])
+	if err != nil {
+		return "", nil, err
+	}
+	return token[:idx], &sc, nil
+}
+
+// parseSampler parses a "<first>,<thereafter>/<interval>" annotation, e.g.
+// "100,100/1s".
+func parseSampler(annotation string) (SamplerConfig, error) {
+	usage := errors.Errorf("bad sampler annotation '%s': expected '<first>,<thereafter>/<interval>'", annotation)
+
+	countsAndInterval := strings.SplitN(annotation, "/", 2)
+	if len(countsAndInterval) != 2 {
+		return SamplerConfig{}, usage
+	}
+
+	counts := strings.SplitN(countsAndInterval[0], ",", 2)
+	if len(counts) != 2 {
+		return SamplerConfig{}, usage
+	}
+
+	first, err := strconv.Atoi(counts[0])
+	if err != nil {
+		return SamplerConfig{}, usage
+	}
+	thereafter, err := strconv.Atoi(counts[1])
+	if err != nil {
+		return SamplerConfig{}, usage
+	}
+	interval, err := time.ParseDuration(countsAndInterval[1])
+	if err != nil {
+		return SamplerConfig{}, usage
+	}
+
+	if first < 0 || thereafter < 0 || interval <= 0 {
+		return SamplerConfig{}, usage
+	}
+
+	return SamplerConfig{First: first, Thereafter: thereafter, Interval: interval}, nil
+}
+
+// String renders a SamplerConfig back into its "<first>,<thereafter>/<interval>" form.
+func (s SamplerConfig) String() string {
+	return fmt.Sprintf("%d,%d/%s", s.First, s.Thereafter, s.Interval)
+}
+
 // logggerNameRegexp defines the valid logger names
 var loggerNameRegexp = regexp.MustCompile(`^[[:alnum:]_#:-]+(\.[[:alnum:]_#:-]+)*$`)
 
@@ -131,6 +240,92 @@ func (m *ModuleLevels) calculateLevel(loggerName string) zapcore.Level {
 	}
 }
 
+// SetLevel sets the logging level for a specific logger. Unlike a spec
+// segment, the change is exact: it affects loggerName only, not any logger
+// nested underneath it. Only the cache entry for loggerName is invalidated;
+// every other logger's cached level is left untouched.
+func (m *ModuleLevels) SetLevel(loggerName string, lvl zapcore.Level) {
+	m.mutex.Lock()
+	m.specs[loggerName+"."] = lvl
+	delete(m.levelCache, loggerName)
+	m.mutex.Unlock()
+}
+
+// SetLevels sets the logging level of every registered logger (see
+// Register) whose name matches pattern, a regular expression. It returns
+// the names of the loggers that were updated, sorted alphabetically. As
+// with SetLevel, the change is exact per logger and only the affected
+// cache entries are invalidated.
+func (m *ModuleLevels) SetLevels(pattern string, lvl zapcore.Level) (matched []string, err error) {
+	re, err := regexp.Compile(pattern)
+	if err != nil {
+		return nil, errors.Errorf("invalid logger pattern '%s': %s", pattern, err)
+	}
+
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	for loggerName := range m.registered {
+		if re.MatchString(loggerName) {
+			m.specs[loggerName+"."] = lvl
+			delete(m.levelCache, loggerName)
+			matched = append(matched, loggerName)
+		}
+	}
+
+	sort.Strings(matched)
+	return matched, nil
+}
+
+// ResetLevel removes any level set for loggerName by SetLevel or SetLevels,
+// reverting it to whatever level the active spec produces.
+func (m *ModuleLevels) ResetLevel(loggerName string) {
+	m.mutex.Lock()
+	delete(m.specs, loggerName+".")
+	delete(m.levelCache, loggerName)
+	m.mutex.Unlock()
+}
+
+// Sampler returns the sampler configuration that applies to loggerName,
+// walking up the logger hierarchy the same way Level does. The default
+// sampler applies only to loggers whose level is the default level, i.e.
+// that no spec entry matches. ok is false if no sampler, including no
+// default sampler, applies.
+func (m *ModuleLevels) Sampler(loggerName string) (sc SamplerConfig, ok bool) {
+	m.mutex.RLock()
+	defer m.mutex.RUnlock()
+
+	candidate := loggerName + "."
+	explicitLevel := false
+	for {
+		if s, ok := m.samplers[candidate]; ok {
+			return s, true
+		}
+		if _, ok := m.specs[candidate]; ok {
+			explicitLevel = true
+		}
+
+		idx := strings.LastIndex(candidate, ".")
+		if idx <= 0 {
+			break
+		}
+		candidate = candidate[:idx]
+	}
+
+	if m.defaultSampler != nil && !explicitLevel {
+		return *m.defaultSampler, true
+	}
+	return SamplerConfig{}, false
+}
+
+// SetDefaultSampler sets the sampler configuration applied to loggers that
+// resolve to the default level through the active spec.
+func (m *ModuleLevels) SetDefaultSampler(sc SamplerConfig) {
+	m.mutex.Lock()
+	m.defaultSampler = &sc
+	m.mutex.Unlock()
+}
+
 // cachedLevel attempts to retrieve the effective log level for a logger from the
 // cache. If the logger is not found, ok will be false.
 func (m *ModuleLevels) cachedLevel(loggerName string) (lvl zapcore.Level, ok bool) {
@@ -147,11 +342,20 @@ func (m *ModuleLevels) Spec() string {
 
 	var fields []string
 	for k, v := range m.specs {
-		fields = append(fields, fmt.Sprintf("%s=%s", k, v))
+		field := fmt.Sprintf("%s=%s", k, v)
+		if sc, ok := m.samplers[k]; ok {
+			field += "@" + sc.String()
+		}
+		fields = append(fields, field)
 	}
 
 	sort.Strings(fields)
-	fields = append(fields, m.defaultLevel.String())
+
+	defaultField := m.defaultLevel.String()
+	if m.defaultSampler != nil {
+		defaultField += "@" + m.defaultSampler.String()
+	}
+	fields = append(fields, defaultField)
 
 	return strings.Join(fields, ":")
 }
@@ -0,0 +1,62 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package flogging
+
+import (
+	"testing"
+
+	"go.uber.org/zap/zapcore"
+)
+
+func TestSetLevelsMatchesRegisteredLoggerAfterSpecReload(t *testing.T) {
+	l, err := New(Config{LogSpec: "info"})
+	if err != nil {
+		t.Fatalf("New returned an error: %s", err)
+	}
+
+	// The logger is only registered by being created; it has not logged
+	// anything, so it would never appear in levelCache.
+	l.Logger("gossip.state")
+
+	// ActivateSpec wipes levelCache but must not forget that the logger was
+	// ever registered.
+	if err := l.ActivateSpec("info"); err != nil {
+		t.Fatalf("ActivateSpec returned an error: %s", err)
+	}
+
+	matched, err := l.SetLevels("^gossip\\.", zapcore.DebugLevel)
+	if err != nil {
+		t.Fatalf("SetLevels returned an error: %s", err)
+	}
+
+	if len(matched) != 1 || matched[0] != "gossip.state" {
+		t.Fatalf("expected SetLevels to match the registered 'gossip.state' logger, got %v", matched)
+	}
+	if got := l.Level("gossip.state"); got != zapcore.DebugLevel {
+		t.Fatalf("expected gossip.state's level to be set to debug, got %s", got)
+	}
+}
+
+func TestActivateSpecRejectsInvalidSamplerCounts(t *testing.T) {
+	l, err := New(Config{})
+	if err != nil {
+		t.Fatalf("New returned an error: %s", err)
+	}
+
+	tests := []string{
+		"gossip=debug@-5,-3/-1s",
+		"gossip=debug@-1,100/1s",
+		"gossip=debug@100,-1/1s",
+		"gossip=debug@100,100/0s",
+		"gossip=debug@100,100/-1s",
+	}
+	for _, spec := range tests {
+		if err := l.ActivateSpec(spec); err == nil {
+			t.Fatalf("expected ActivateSpec(%q) to reject the invalid sampler annotation", spec)
+		}
+	}
+}
@@ -0,0 +1,165 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package flogging
+
+import (
+	"sync"
+
+	"go.uber.org/multierr"
+	"go.uber.org/zap/zapcore"
+)
+
+// MultiCore is a zapcore.Core that fans every record out to a dynamically
+// managed set of named child cores. Cores can be attached and detached at
+// runtime with AddCore and RemoveCore, which take effect immediately for
+// every logger already built on top of the MultiCore -- no logger needs to
+// be rebuilt. This enables use cases like attaching an in-memory ring
+// buffer core for crash dumps, a syslog/GELF core, or a test-capture core.
+//
+// MultiCore is safe for concurrent use.
+type MultiCore struct {
+	mutex sync.RWMutex
+	cores map[string]zapcore.Core
+}
+
+// NewMultiCore creates a MultiCore seeded with the given named cores.
+func NewMultiCore(cores map[string]zapcore.Core) *MultiCore {
+	seeded := make(map[string]zapcore.Core, len(cores))
+	for name, c := range cores {
+		seeded[name] = c
+	}
+	return &MultiCore{cores: seeded}
+}
+
+// AddCore attaches c under name, replacing any core already registered
+// under that name.
+func (m *MultiCore) AddCore(name string, c zapcore.Core) {
+	m.mutex.Lock()
+	m.cores[name] = c
+	m.mutex.Unlock()
+}
+
+// RemoveCore detaches the core registered under name, if any.
+func (m *MultiCore) RemoveCore(name string) {
+	m.mutex.Lock()
+	delete(m.cores, name)
+	m.mutex.Unlock()
+}
+
+// Enabled reports whether any attached core is enabled for lvl. The cores
+// are consulted under the lock so a core attached concurrently with a
+// stricter or looser level takes effect immediately.
+func (m *MultiCore) Enabled(lvl zapcore.Level) bool {
+	m.mutex.RLock()
+	defer m.mutex.RUnlock()
+
+	for _, c := range m.cores {
+		if c.Enabled(lvl) {
+			return true
+		}
+	}
+	return false
+}
+
+// With returns a core that carries fields in addition to whatever the
+// attached cores already carry. It deliberately does not snapshot the
+// current set of attached cores: doing so would pin AddCore/RemoveCore
+// calls issued afterward to whatever was attached at the moment With was
+// called (for example, whenever FABRIC_LOGGING_LABELS binds base fields on
+// every logger at startup), defeating the point of a runtime-attachable
+// core. Instead, the returned core re-reads m.cores on every Check.
+func (m *MultiCore) With(fields []zapcore.Field) zapcore.Core {
+	if len(fields) == 0 {
+		return m
+	}
+	return &multiCoreWithFields{multiCore: m, fields: fields}
+}
+
+// Check consults the cores attached at the time of the call, under the
+// lock, so a core attached or removed after a logger was built takes
+// effect on that logger's next Check. A CheckedEntry already returned keeps
+// the cores that were enabled when it was created.
+func (m *MultiCore) Check(e zapcore.Entry, ce *zapcore.CheckedEntry) *zapcore.CheckedEntry {
+	return m.checkWithFields(e, ce, nil)
+}
+
+// checkWithFields is Check, plus base fields bound to every enabled core
+// before it is added to ce. The attached cores are re-read under the lock
+// on every call rather than once up front, so it is safe to call long
+// after With bound fields -- any core attached or removed in between is
+// reflected here.
+func (m *MultiCore) checkWithFields(e zapcore.Entry, ce *zapcore.CheckedEntry, fields []zapcore.Field) *zapcore.CheckedEntry {
+	m.mutex.RLock()
+	defer m.mutex.RUnlock()
+
+	for _, c := range m.cores {
+		if !c.Enabled(e.Level) {
+			continue
+		}
+		if len(fields) > 0 {
+			c = c.With(fields)
+		}
+		ce = c.Check(e, ce)
+	}
+	return ce
+}
+
+// Write delivers e and fields to every attached core, aggregating any
+// errors with multierr so that one failing sink does not mask the others.
+func (m *MultiCore) Write(e zapcore.Entry, fields []zapcore.Field) error {
+	m.mutex.RLock()
+	defer m.mutex.RUnlock()
+
+	var err error
+	for _, c := range m.cores {
+		err = multierr.Append(err, c.Write(e, fields))
+	}
+	return err
+}
+
+// Sync flushes every attached core, aggregating any errors with multierr.
+func (m *MultiCore) Sync() error {
+	m.mutex.RLock()
+	defer m.mutex.RUnlock()
+
+	var err error
+	for _, c := range m.cores {
+		err = multierr.Append(err, c.Sync())
+	}
+	return err
+}
+
+// multiCoreWithFields carries base fields forward from a call to
+// MultiCore.With without forking the live core registry; see the comment
+// on With for why that matters. Check re-derives which cores are enabled,
+// and with fields attached, from the parent MultiCore on every call.
+type multiCoreWithFields struct {
+	multiCore *MultiCore
+	fields    []zapcore.Field
+}
+
+func (c *multiCoreWithFields) Enabled(lvl zapcore.Level) bool { return c.multiCore.Enabled(lvl) }
+
+func (c *multiCoreWithFields) With(fields []zapcore.Field) zapcore.Core {
+	merged := make([]zapcore.Field, 0, len(c.fields)+len(fields))
+	merged = append(merged, c.fields...)
+	merged = append(merged, fields...)
+	return &multiCoreWithFields{multiCore: c.multiCore, fields: merged}
+}
+
+func (c *multiCoreWithFields) Check(e zapcore.Entry, ce *zapcore.CheckedEntry) *zapcore.CheckedEntry {
+	return c.multiCore.checkWithFields(e, ce, c.fields)
+}
+
+func (c *multiCoreWithFields) Write(e zapcore.Entry, fields []zapcore.Field) error {
+	merged := make([]zapcore.Field, 0, len(c.fields)+len(fields))
+	merged = append(merged, c.fields...)
+	merged = append(merged, fields...)
+	return c.multiCore.Write(e, merged)
+}
+
+func (c *multiCoreWithFields) Sync() error { return c.multiCore.Sync() }
@@ -0,0 +1,74 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package flogging
+
+import (
+	"testing"
+
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+// capturingCore records every entry written to it so tests can assert on
+// what a sideband core observed. It is enabled at the levels its
+// LevelEnabler allows; newCapturingCore enables it from DebugLevel up.
+type capturingCore struct {
+	zapcore.LevelEnabler
+	entries *[]zapcore.Entry
+}
+
+func newCapturingCore() *capturingCore {
+	return &capturingCore{LevelEnabler: zapcore.DebugLevel, entries: &[]zapcore.Entry{}}
+}
+
+func (c *capturingCore) With(fields []zapcore.Field) zapcore.Core { return c }
+
+func (c *capturingCore) Check(e zapcore.Entry, ce *zapcore.CheckedEntry) *zapcore.CheckedEntry {
+	return ce.AddCore(e, c)
+}
+
+func (c *capturingCore) Write(e zapcore.Entry, fields []zapcore.Field) error {
+	*c.entries = append(*c.entries, e)
+	return nil
+}
+
+func (c *capturingCore) Sync() error { return nil }
+
+func TestMultiCoreAddCoreAfterWithFields(t *testing.T) {
+	mc := NewMultiCore(nil)
+
+	// Mirror FABRIC_LOGGING_LABELS: base fields are bound once, up front,
+	// on top of the MultiCore -- exactly what ZapLogger does when labels
+	// are configured.
+	labeled := mc.With([]zapcore.Field{zap.String("org", "org1")})
+	logger := zap.New(labeled)
+
+	// AddCore happens after the labeled logger already exists.
+	sideband := newCapturingCore()
+	mc.AddCore("sideband", sideband)
+
+	logger.Info("hello")
+
+	if len(*sideband.entries) != 1 {
+		t.Fatalf("expected the sideband core attached after With() to receive the log record, got %d entries", len(*sideband.entries))
+	}
+}
+
+func TestMultiCoreCheckRespectsPerCoreLevel(t *testing.T) {
+	mc := NewMultiCore(nil)
+
+	strict := &capturingCore{LevelEnabler: zapcore.WarnLevel, entries: &[]zapcore.Entry{}}
+	mc.AddCore("strict", strict)
+
+	logger := zap.New(mc)
+	logger.Info("should not reach the warn-level core")
+	logger.Warn("should reach the warn-level core")
+
+	if len(*strict.entries) != 1 {
+		t.Fatalf("expected only the Warn record to reach a core enabled at WarnLevel, got %d entries", len(*strict.entries))
+	}
+}
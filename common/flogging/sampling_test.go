@@ -0,0 +1,44 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package flogging
+
+import "testing"
+
+func TestSamplerConfigAppliesAfterSpecReload(t *testing.T) {
+	l, err := New(Config{LogSpec: "debug"})
+	if err != nil {
+		t.Fatalf("New returned an error: %s", err)
+	}
+
+	captured := newCapturingCore()
+	l.AddCore("captured", captured)
+
+	// The logger is created before sampling is ever activated, mirroring a
+	// package-level `var logger = flogging.MustGetLogger(...)`.
+	logger := l.Logger("gossip")
+	for i := 0; i < 5; i++ {
+		logger.Debug("hello")
+	}
+	if got := len(*captured.entries); got != 5 {
+		t.Fatalf("expected all 5 records before sampling was activated, got %d", got)
+	}
+	*captured.entries = nil
+
+	// Only the first record of a given message should pass per tick, with
+	// the next one not due for a very long time.
+	if err := l.ActivateSpec("gossip=debug@1,1000000/1h"); err != nil {
+		t.Fatalf("ActivateSpec returned an error: %s", err)
+	}
+
+	for i := 0; i < 5; i++ {
+		logger.Debug("hello")
+	}
+
+	if got := len(*captured.entries); got != 1 {
+		t.Fatalf("expected the pre-existing logger to observe the spec reloaded after it was created and sample down to 1 record, got %d", got)
+	}
+}